@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/inspect"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+type inspectOptions struct {
+	refs   []string
+	format string
+	pretty bool
+}
+
+func newInspectCommand(dockerCli command.Cli) *cobra.Command {
+	var opts inspectOptions
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] SERVICE [SERVICE...]",
+		Short: "Display detailed information on one or more services",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.refs = args
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+	flags.BoolVar(&opts.pretty, "pretty", false, "Print the information in a human friendly format")
+	return cmd
+}
+
+func runInspect(dockerCli command.Cli, opts inspectOptions) error {
+	apiClient := dockerCli.Client()
+	ctx := context.Background()
+
+	if opts.pretty {
+		opts.format = "pretty"
+	}
+
+	getRef := func(ref string) (interface{}, []byte, error) {
+		service, _, err := apiClient.ServiceInspectWithRaw(ctx, ref, types.ServiceInspectOptions{InsertDefaults: opts.format == "pretty"})
+		if err == nil || !client.IsErrNotFound(err) {
+			return service, nil, err
+		}
+		return nil, nil, fmt.Errorf("Error: no such service: %s", ref)
+	}
+
+	if opts.format == "pretty" {
+		return inspect.Inspect(dockerCli.Out(), opts.refs, "pretty", getRef, printHumanFriendly)
+	}
+	return inspect.Inspect(dockerCli.Out(), opts.refs, opts.format, getRef)
+}
+
+// printHumanFriendly renders the service spec returned with insertDefaults
+// set, so the reservations and policies shown are the ones the scheduler
+// actually applies rather than the sparse spec the CLI sent.
+func printHumanFriendly(out io.Writer, ref interface{}) error {
+	service, ok := ref.(swarm.Service)
+	if !ok {
+		return fmt.Errorf("unexpected type for pretty-print: %T", ref)
+	}
+
+	fmt.Fprintf(out, "ID:\t\t%s\n", service.ID)
+	fmt.Fprintf(out, "Name:\t\t%s\n", service.Spec.Name)
+	if rp := service.Spec.TaskTemplate.RestartPolicy; rp != nil {
+		fmt.Fprintf(out, "Restart Policy:\t%s\n", rp.Condition)
+	}
+	if uc := service.Spec.UpdateConfig; uc != nil {
+		fmt.Fprintf(out, "Update Config:\n")
+		fmt.Fprintf(out, " Parallelism:\t%d\n", uc.Parallelism)
+		fmt.Fprintf(out, " On failure:\t%s\n", uc.FailureAction)
+	}
+	if res := service.Spec.TaskTemplate.Resources; res != nil && res.Reservations != nil {
+		fmt.Fprintf(out, "Resources:\n")
+		fmt.Fprintf(out, " Reservations:\n")
+		if res.Reservations.NanoCPUs != 0 {
+			fmt.Fprintf(out, "  CPU:\t\t%g\n", float64(res.Reservations.NanoCPUs)/1e9)
+		}
+		if res.Reservations.MemoryBytes != 0 {
+			fmt.Fprintf(out, "  Memory:\t%d MiB\n", res.Reservations.MemoryBytes/1024/1024)
+		}
+	}
+	return nil
+}
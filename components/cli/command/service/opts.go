@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bufio"
 	"encoding/csv"
 	"fmt"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"github.com/docker/go-connections/nat"
 	units "github.com/docker/go-units"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 type int64Value interface {
@@ -234,34 +236,255 @@ func (o *SecretOpt) Value() []*SecretRequestSpec {
 	return o.values
 }
 
+// ConfigRequestSpec is a type for requesting configs
+type ConfigRequestSpec struct {
+	source string
+	target string
+	uid    string
+	gid    string
+	mode   os.FileMode
+}
+
+// ConfigOpt is a Value type for parsing configs
+type ConfigOpt struct {
+	values []*ConfigRequestSpec
+}
+
+// Set a new config value
+func (o *ConfigOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	spec := &ConfigRequestSpec{
+		source: "",
+		target: "",
+		uid:    "0",
+		gid:    "0",
+		mode:   0444,
+	}
+
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		value := parts[1]
+		switch key {
+		case "source":
+			spec.source = value
+		case "target":
+			// unlike secrets, configs may be mounted anywhere in the
+			// container, so an absolute target path is allowed.
+			spec.target = value
+		case "uid":
+			spec.uid = value
+		case "gid":
+			spec.gid = value
+		case "mode":
+			m, err := strconv.ParseUint(value, 0, 32)
+			if err != nil {
+				return fmt.Errorf("invalid mode specified: %v", err)
+			}
+
+			spec.mode = os.FileMode(m)
+		default:
+			return fmt.Errorf("invalid field in config request: %s", key)
+		}
+	}
+
+	if spec.source == "" {
+		return fmt.Errorf("source is required")
+	}
+
+	o.values = append(o.values, spec)
+	return nil
+}
+
+// Type returns the type of this option
+func (o *ConfigOpt) Type() string {
+	return "config"
+}
+
+// String returns a string repr of this option
+func (o *ConfigOpt) String() string {
+	configs := []string{}
+	for _, config := range o.values {
+		repr := fmt.Sprintf("%s -> %s", config.source, config.target)
+		configs = append(configs, repr)
+	}
+	return strings.Join(configs, ", ")
+}
+
+// Value returns the config requests
+func (o *ConfigOpt) Value() []*ConfigRequestSpec {
+	return o.values
+}
+
+// placementPrefOpts is a Value type for parsing placement preferences
+type placementPrefOpts struct {
+	prefs   []swarm.PlacementPreference
+	strings []string
+}
+
+func (opts *placementPrefOpts) String() string {
+	return fmt.Sprintf("%v", opts.strings)
+}
+
+// Set parses a placement preference CSV spec, e.g. "strategy=spread,spread=node.labels.zone"
+func (opts *placementPrefOpts) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	var strategy string
+	var spreadDescriptor string
+
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		key, val := parts[0], parts[1]
+
+		switch key {
+		case "strategy":
+			strategy = val
+		case "spread":
+			spreadDescriptor = val
+		default:
+			return fmt.Errorf("invalid field in placement preference: %s", key)
+		}
+	}
+
+	switch strategy {
+	case "spread":
+		opts.prefs = append(opts.prefs, swarm.PlacementPreference{
+			Spread: &swarm.SpreadOver{
+				SpreadDescriptor: spreadDescriptor,
+			},
+		})
+	case "":
+		return fmt.Errorf("strategy is required")
+	default:
+		return fmt.Errorf("unsupported placement preference strategy: %s", strategy)
+	}
+
+	opts.strings = append(opts.strings, value)
+	return nil
+}
+
+// Type returns the type of this option
+func (opts *placementPrefOpts) Type() string {
+	return "pref"
+}
+
 type updateOptions struct {
 	parallelism     uint64
 	delay           time.Duration
 	monitor         time.Duration
 	onFailure       string
 	maxFailureRatio floatValue
+	order           string
 }
 
 type resourceOptions struct {
-	limitCPU      opts.NanoCPUs
-	limitMemBytes memBytes
-	resCPU        opts.NanoCPUs
-	resMemBytes   memBytes
+	limitCPU            opts.NanoCPUs
+	limitMemBytes       memBytes
+	resCPU              opts.NanoCPUs
+	resMemBytes         memBytes
+	resGenericResources genericResourceOpts
 }
 
 func (r *resourceOptions) ToResourceRequirements() *swarm.ResourceRequirements {
 	return &swarm.ResourceRequirements{
 		Limits: &swarm.Resources{
-			NanoCPUs:    r.limitCPU.Value(),
-			MemoryBytes: r.limitMemBytes.Value(),
+			NanoCPUs:         r.limitCPU.Value(),
+			MemoryBytes:      r.limitMemBytes.Value(),
+			GenericResources: r.resGenericResources.Value(),
 		},
 		Reservations: &swarm.Resources{
-			NanoCPUs:    r.resCPU.Value(),
-			MemoryBytes: r.resMemBytes.Value(),
+			NanoCPUs:         r.resCPU.Value(),
+			MemoryBytes:      r.resMemBytes.Value(),
+			GenericResources: r.resGenericResources.Value(),
 		},
 	}
 }
 
+// genericResourceOpts is a Value type for parsing --generic-resource specs,
+// e.g. "gpu=2,ssd=1" for discrete counts or "gpu=UUID-xxxx" for named
+// instances advertised by a node.
+type genericResourceOpts struct {
+	values []swarm.GenericResource
+}
+
+// Set parses a comma-separated list of name=value generic resource specs.
+func (g *genericResourceOpts) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		kind, val := parts[0], parts[1]
+
+		if count, err := strconv.ParseInt(val, 10, 64); err == nil {
+			g.values = append(g.values, swarm.GenericResource{
+				DiscreteResourceSpec: &swarm.DiscreteGenericResource{
+					Kind:  kind,
+					Value: count,
+				},
+			})
+			continue
+		}
+
+		g.values = append(g.values, swarm.GenericResource{
+			NamedResourceSpec: &swarm.NamedGenericResource{
+				Kind:  kind,
+				Value: val,
+			},
+		})
+	}
+
+	return nil
+}
+
+// Type returns the type of this option
+func (g *genericResourceOpts) Type() string {
+	return "generic-resources"
+}
+
+// String returns a string repr of this option
+func (g *genericResourceOpts) String() string {
+	var values []string
+	for _, v := range g.values {
+		switch {
+		case v.DiscreteResourceSpec != nil:
+			values = append(values, fmt.Sprintf("%s=%d", v.DiscreteResourceSpec.Kind, v.DiscreteResourceSpec.Value))
+		case v.NamedResourceSpec != nil:
+			values = append(values, fmt.Sprintf("%s=%s", v.NamedResourceSpec.Kind, v.NamedResourceSpec.Value))
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// Value returns the generic resources parsed so far
+func (g *genericResourceOpts) Value() []swarm.GenericResource {
+	return g.values
+}
+
 type restartPolicyOptions struct {
 	condition   string
 	delay       DurationOpt
@@ -278,6 +501,24 @@ func (r *restartPolicyOptions) ToRestartPolicy() *swarm.RestartPolicy {
 	}
 }
 
+// convertConfigs turns the parsed --config specs into the swarm config
+// references attached to a task's ContainerSpec.
+func convertConfigs(configs []*ConfigRequestSpec) []*swarm.ConfigReference {
+	var refs []*swarm.ConfigReference
+	for _, config := range configs {
+		refs = append(refs, &swarm.ConfigReference{
+			ConfigName: config.source,
+			File: &swarm.ConfigReferenceFileTarget{
+				Name: config.target,
+				UID:  config.uid,
+				GID:  config.gid,
+				Mode: config.mode,
+			},
+		})
+	}
+	return refs
+}
+
 func convertNetworks(networks []string) []swarm.NetworkAttachmentConfig {
 	nets := []swarm.NetworkAttachmentConfig{}
 	for _, network := range networks {
@@ -422,6 +663,7 @@ type serviceOptions struct {
 	hostname        string
 	env             opts.ListOpts
 	envFile         opts.ListOpts
+	envExpand       bool
 	workdir         string
 	user            string
 	groups          opts.ListOpts
@@ -440,7 +682,9 @@ type serviceOptions struct {
 
 	restartPolicy restartPolicyOptions
 	constraints   opts.ListOpts
+	placementPref placementPrefOpts
 	update        updateOptions
+	rollback      updateOptions
 	networks      opts.ListOpts
 	endpoint      endpointOptions
 
@@ -450,6 +694,7 @@ type serviceOptions struct {
 
 	healthcheck healthCheckOptions
 	secrets     opts.SecretOpt
+	configs     ConfigOpt
 }
 
 func newServiceOptions() *serviceOptions {
@@ -472,26 +717,106 @@ func newServiceOptions() *serviceOptions {
 	}
 }
 
-func (opts *serviceOptions) ToService() (swarm.ServiceSpec, error) {
-	var service swarm.ServiceSpec
+// anyChanged returns true if any of the given flags were explicitly set.
+func anyChanged(flags *pflag.FlagSet, names ...string) bool {
+	for _, name := range names {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// processEnv resolves --env-file and --env into a single, ordered list of
+// KEY=VALUE strings. env-file lines are tokenized with parseShellWords so a
+// quoted value keeps embedded spaces, --env-expand resolves $VAR/${VAR}
+// references against the CLI's own environment, and duplicates are
+// collapsed to the last value seen while keeping each key's original
+// position stable.
+func (opts *serviceOptions) processEnv() ([]string, error) {
+	var rawVars []string
+	for _, file := range opts.envFile.GetAll() {
+		lines, err := readEnvFile(file)
+		if err != nil {
+			return nil, err
+		}
+		rawVars = append(rawVars, lines...)
+	}
+	rawVars = append(rawVars, opts.env.GetAll()...)
+
+	vars := make([]string, 0, len(rawVars))
+	for _, v := range rawVars {
+		if opts.envExpand {
+			v = expandEnvVar(v)
+		}
+		vars = append(vars, v)
+	}
+
+	return orderedDedupEnv(vars), nil
+}
 
-	envVariables, err := runconfigopts.ReadKVStrings(opts.envFile.GetAll(), opts.env.GetAll())
+// readEnvFile reads a --env-file, skipping blank lines and comments, and
+// tokenizes each remaining line with parseShellWords.
+func readEnvFile(path string) ([]string, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		return service, err
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words, err := parseShellWords(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid env-file %s: %v", path, err)
+		}
+		if len(words) != 1 {
+			return nil, fmt.Errorf("invalid env-file %s: line %q has unquoted whitespace; quote the value or remove trailing comments", path, line)
+		}
+		lines = append(lines, words[0])
+	}
+	return lines, scanner.Err()
+}
+
+// expandEnvVar resolves $VAR/${VAR} references in a KEY=VALUE string's
+// value against the CLI's own environment.
+func expandEnvVar(env string) string {
+	parts := strings.SplitN(env, "=", 2)
+	if len(parts) != 2 {
+		return env
 	}
+	return parts[0] + "=" + os.Expand(parts[1], os.Getenv)
+}
 
-	currentEnv := make([]string, 0, len(envVariables))
-	for _, env := range envVariables { // need to process each var, in order
-		k := strings.SplitN(env, "=", 2)[0]
-		for i, current := range currentEnv { // remove duplicates
-			if current == env {
-				continue // no update required, may hide this behind flag to preserve order of envVariables
-			}
-			if strings.HasPrefix(current, k+"=") {
-				currentEnv = append(currentEnv[:i], currentEnv[i+1:]...)
-			}
+// orderedDedupEnv collapses duplicate keys to their last value while
+// keeping each key's first position stable, so re-declaring a variable
+// overrides it in place instead of reshuffling unrelated ones.
+func orderedDedupEnv(vars []string) []string {
+	index := make(map[string]int, len(vars))
+	result := make([]string, 0, len(vars))
+	for _, v := range vars {
+		k := strings.SplitN(v, "=", 2)[0]
+		if i, ok := index[k]; ok {
+			result[i] = v
+			continue
 		}
-		currentEnv = append(currentEnv, env)
+		index[k] = len(result)
+		result = append(result, v)
+	}
+	return result
+}
+
+func (opts *serviceOptions) ToService(flags *pflag.FlagSet) (swarm.ServiceSpec, error) {
+	var service swarm.ServiceSpec
+
+	currentEnv, err := opts.processEnv()
+	if err != nil {
+		return service, err
 	}
 
 	service = swarm.ServiceSpec{
@@ -519,25 +844,52 @@ func (opts *serviceOptions) ToService() (swarm.ServiceSpec, error) {
 				Hosts:           convertExtraHostsToSwarmHosts(opts.hosts.GetAll()),
 				StopGracePeriod: opts.stopGrace.Value(),
 				Secrets:         nil,
+				Configs:         convertConfigs(opts.configs.Value()),
 			},
-			Networks:      convertNetworks(opts.networks.GetAll()),
-			Resources:     opts.resources.ToResourceRequirements(),
-			RestartPolicy: opts.restartPolicy.ToRestartPolicy(),
+			Networks: convertNetworks(opts.networks.GetAll()),
 			Placement: &swarm.Placement{
 				Constraints: opts.constraints.GetAll(),
+				Preferences: opts.placementPref.prefs,
 			},
 			LogDriver: opts.logDriver.toLogDriver(),
 		},
-		Networks: convertNetworks(opts.networks.GetAll()),
-		Mode:     swarm.ServiceMode{},
-		UpdateConfig: &swarm.UpdateConfig{
+		Networks:     convertNetworks(opts.networks.GetAll()),
+		Mode:         swarm.ServiceMode{},
+		EndpointSpec: opts.endpoint.ToEndpointSpec(),
+	}
+
+	if anyChanged(flags, flagRestartCondition, flagRestartDelay, flagRestartMaxAttempts, flagRestartWindow) {
+		service.TaskTemplate.RestartPolicy = opts.restartPolicy.ToRestartPolicy()
+	}
+
+	if anyChanged(flags, flagLimitCPU, flagLimitMemory, flagReserveCPU, flagReserveMemory, flagGenericResources) {
+		service.TaskTemplate.Resources = opts.resources.ToResourceRequirements()
+	}
+
+	if anyChanged(flags, flagUpdateParallelism, flagUpdateDelay, flagUpdateMonitor, flagUpdateFailureAction, flagUpdateMaxFailureRatio, flagUpdateOrder) {
+		service.UpdateConfig = &swarm.UpdateConfig{
 			Parallelism:     opts.update.parallelism,
 			Delay:           opts.update.delay,
 			Monitor:         opts.update.monitor,
 			FailureAction:   opts.update.onFailure,
 			MaxFailureRatio: opts.update.maxFailureRatio.Value(),
-		},
-		EndpointSpec: opts.endpoint.ToEndpointSpec(),
+			Order:           opts.update.order,
+		}
+	}
+
+	if anyChanged(flags, flagRollbackParallelism, flagRollbackDelay, flagRollbackMonitor, flagRollbackFailureAction, flagRollbackMaxFailureRatio, flagRollbackOrder) {
+		service.RollbackConfig = &swarm.UpdateConfig{
+			Parallelism:     opts.rollback.parallelism,
+			Delay:           opts.rollback.delay,
+			Monitor:         opts.rollback.monitor,
+			FailureAction:   opts.rollback.onFailure,
+			MaxFailureRatio: opts.rollback.maxFailureRatio.Value(),
+			Order:           opts.rollback.order,
+		}
+	}
+
+	if opts.update.onFailure == "rollback" && service.RollbackConfig == nil {
+		return service, fmt.Errorf("update-failure-action=rollback requires a rollback configuration")
 	}
 
 	healthConfig, err := opts.healthcheck.toHealthConfig()
@@ -589,6 +941,16 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 	flags.DurationVar(&opts.update.monitor, flagUpdateMonitor, time.Duration(0), "Duration after each task update to monitor for failure (ns|us|ms|s|m|h) (default 0s)")
 	flags.StringVar(&opts.update.onFailure, flagUpdateFailureAction, "pause", "Action on update failure (pause|continue)")
 	flags.Var(&opts.update.maxFailureRatio, flagUpdateMaxFailureRatio, "Failure rate to tolerate during an update")
+	flags.StringVar(&opts.update.order, flagUpdateOrder, "stop-first", "Update order (start-first|stop-first)")
+
+	flags.Uint64Var(&opts.rollback.parallelism, flagRollbackParallelism, 1, "Rollback parallelism (0 to rollback all at once)")
+	flags.DurationVar(&opts.rollback.delay, flagRollbackDelay, time.Duration(0), "Delay between task rollbacks (ns|us|ms|s|m|h)")
+	flags.DurationVar(&opts.rollback.monitor, flagRollbackMonitor, time.Duration(0), "Duration after each task rollback to monitor for failure (ns|us|ms|s|m|h)")
+	flags.StringVar(&opts.rollback.onFailure, flagRollbackFailureAction, "pause", "Action on rollback failure (pause|continue)")
+	flags.Var(&opts.rollback.maxFailureRatio, flagRollbackMaxFailureRatio, "Failure rate to tolerate during a rollback")
+	flags.StringVar(&opts.rollback.order, flagRollbackOrder, "stop-first", "Rollback order (start-first|stop-first)")
+
+	flags.BoolVar(&opts.envExpand, flagEnvExpand, false, "Resolve $VAR/${VAR} references in --env and --env-file values")
 
 	flags.StringVar(&opts.endpoint.mode, flagEndpointMode, "", "Endpoint mode (vip or dnsrr)")
 
@@ -607,75 +969,94 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 }
 
 const (
-	flagConstraint            = "constraint"
-	flagConstraintRemove      = "constraint-rm"
-	flagConstraintAdd         = "constraint-add"
-	flagContainerLabel        = "container-label"
-	flagContainerLabelRemove  = "container-label-rm"
-	flagContainerLabelAdd     = "container-label-add"
-	flagDNS                   = "dns"
-	flagDNSRemove             = "dns-rm"
-	flagDNSAdd                = "dns-add"
-	flagDNSOption             = "dns-option"
-	flagDNSOptionRemove       = "dns-option-rm"
-	flagDNSOptionAdd          = "dns-option-add"
-	flagDNSSearch             = "dns-search"
-	flagDNSSearchRemove       = "dns-search-rm"
-	flagDNSSearchAdd          = "dns-search-add"
-	flagEndpointMode          = "endpoint-mode"
-	flagHost                  = "host"
-	flagHostAdd               = "host-add"
-	flagHostRemove            = "host-rm"
-	flagHostname              = "hostname"
-	flagEnv                   = "env"
-	flagEnvFile               = "env-file"
-	flagEnvRemove             = "env-rm"
-	flagEnvAdd                = "env-add"
-	flagGroup                 = "group"
-	flagGroupAdd              = "group-add"
-	flagGroupRemove           = "group-rm"
-	flagLabel                 = "label"
-	flagLabelRemove           = "label-rm"
-	flagLabelAdd              = "label-add"
-	flagLimitCPU              = "limit-cpu"
-	flagLimitMemory           = "limit-memory"
-	flagMode                  = "mode"
-	flagMount                 = "mount"
-	flagMountRemove           = "mount-rm"
-	flagMountAdd              = "mount-add"
-	flagName                  = "name"
-	flagNetwork               = "network"
-	flagPublish               = "publish"
-	flagPublishRemove         = "publish-rm"
-	flagPublishAdd            = "publish-add"
-	flagPort                  = "port"
-	flagPortAdd               = "port-add"
-	flagPortRemove            = "port-rm"
-	flagReplicas              = "replicas"
-	flagReserveCPU            = "reserve-cpu"
-	flagReserveMemory         = "reserve-memory"
-	flagRestartCondition      = "restart-condition"
-	flagRestartDelay          = "restart-delay"
-	flagRestartMaxAttempts    = "restart-max-attempts"
-	flagRestartWindow         = "restart-window"
-	flagStopGracePeriod       = "stop-grace-period"
-	flagTTY                   = "tty"
-	flagUpdateDelay           = "update-delay"
-	flagUpdateFailureAction   = "update-failure-action"
-	flagUpdateMaxFailureRatio = "update-max-failure-ratio"
-	flagUpdateMonitor         = "update-monitor"
-	flagUpdateParallelism     = "update-parallelism"
-	flagUser                  = "user"
-	flagWorkdir               = "workdir"
-	flagRegistryAuth          = "with-registry-auth"
-	flagLogDriver             = "log-driver"
-	flagLogOpt                = "log-opt"
-	flagHealthCmd             = "health-cmd"
-	flagHealthInterval        = "health-interval"
-	flagHealthRetries         = "health-retries"
-	flagHealthTimeout         = "health-timeout"
-	flagNoHealthcheck         = "no-healthcheck"
-	flagSecret                = "secret"
-	flagSecretAdd             = "secret-add"
-	flagSecretRemove          = "secret-rm"
+	flagConstraint              = "constraint"
+	flagConstraintRemove        = "constraint-rm"
+	flagConstraintAdd           = "constraint-add"
+	flagContainerLabel          = "container-label"
+	flagContainerLabelRemove    = "container-label-rm"
+	flagContainerLabelAdd       = "container-label-add"
+	flagDNS                     = "dns"
+	flagDNSRemove               = "dns-rm"
+	flagDNSAdd                  = "dns-add"
+	flagDNSOption               = "dns-option"
+	flagDNSOptionRemove         = "dns-option-rm"
+	flagDNSOptionAdd            = "dns-option-add"
+	flagDNSSearch               = "dns-search"
+	flagDNSSearchRemove         = "dns-search-rm"
+	flagDNSSearchAdd            = "dns-search-add"
+	flagEndpointMode            = "endpoint-mode"
+	flagHost                    = "host"
+	flagHostAdd                 = "host-add"
+	flagHostRemove              = "host-rm"
+	flagHostname                = "hostname"
+	flagEnv                     = "env"
+	flagEnvFile                 = "env-file"
+	flagEnvRemove               = "env-rm"
+	flagEnvAdd                  = "env-add"
+	flagEnvExpand               = "env-expand"
+	flagGroup                   = "group"
+	flagGroupAdd                = "group-add"
+	flagGroupRemove             = "group-rm"
+	flagLabel                   = "label"
+	flagLabelRemove             = "label-rm"
+	flagLabelAdd                = "label-add"
+	flagLimitCPU                = "limit-cpu"
+	flagLimitMemory             = "limit-memory"
+	flagMode                    = "mode"
+	flagMount                   = "mount"
+	flagMountRemove             = "mount-rm"
+	flagMountAdd                = "mount-add"
+	flagName                    = "name"
+	flagNetwork                 = "network"
+	flagPublish                 = "publish"
+	flagPublishRemove           = "publish-rm"
+	flagPublishAdd              = "publish-add"
+	flagPlacementPref           = "placement-pref"
+	flagPlacementPrefAdd        = "placement-pref-add"
+	flagPlacementPrefRemove     = "placement-pref-rm"
+	flagPort                    = "port"
+	flagPortAdd                 = "port-add"
+	flagPortRemove              = "port-rm"
+	flagReplicas                = "replicas"
+	flagReserveCPU              = "reserve-cpu"
+	flagReserveMemory           = "reserve-memory"
+	flagRestartCondition        = "restart-condition"
+	flagRestartDelay            = "restart-delay"
+	flagRestartMaxAttempts      = "restart-max-attempts"
+	flagRestartWindow           = "restart-window"
+	flagStopGracePeriod         = "stop-grace-period"
+	flagTTY                     = "tty"
+	flagUpdateDelay             = "update-delay"
+	flagUpdateFailureAction     = "update-failure-action"
+	flagUpdateMaxFailureRatio   = "update-max-failure-ratio"
+	flagUpdateMonitor           = "update-monitor"
+	flagUpdateOrder             = "update-order"
+	flagUpdateParallelism       = "update-parallelism"
+	flagUser                    = "user"
+	flagWorkdir                 = "workdir"
+	flagRegistryAuth            = "with-registry-auth"
+	flagLogDriver               = "log-driver"
+	flagLogOpt                  = "log-opt"
+	flagHealthCmd               = "health-cmd"
+	flagHealthInterval          = "health-interval"
+	flagHealthRetries           = "health-retries"
+	flagHealthTimeout           = "health-timeout"
+	flagNoHealthcheck           = "no-healthcheck"
+	flagSecret                  = "secret"
+	flagSecretAdd               = "secret-add"
+	flagSecretRemove            = "secret-rm"
+	flagConfig                  = "config"
+	flagConfigAdd               = "config-add"
+	flagConfigRemove            = "config-rm"
+	flagGenericResources        = "generic-resource"
+	flagGenericResourcesAdd     = "generic-resource-add"
+	flagGenericResourcesRemove  = "generic-resource-rm"
+	flagForce                   = "force"
+	flagRollback                = "rollback"
+	flagRollbackDelay           = "rollback-delay"
+	flagRollbackFailureAction   = "rollback-failure-action"
+	flagRollbackMaxFailureRatio = "rollback-max-failure-ratio"
+	flagRollbackMonitor         = "rollback-monitor"
+	flagRollbackOrder           = "rollback-order"
+	flagRollbackParallelism     = "rollback-parallelism"
 )
@@ -0,0 +1,57 @@
+package service
+
+import "fmt"
+
+// parseShellWords splits a single line the way a shell would, so a quoted
+// value such as FOO="a b c" keeps its embedded spaces as part of one word
+// instead of being cut at the first unquoted space. A backslash escapes the
+// next character only outside quotes; inside single or double quotes it is
+// kept as a literal backslash. It backs the env-file reader used by
+// --env-file.
+func parseShellWords(line string) ([]string, error) {
+	var words []string
+	var buf []rune
+	var inWord bool
+	var quote rune
+
+	flush := func() {
+		if inWord {
+			words = append(words, string(buf))
+			buf = buf[:0]
+			inWord = false
+		}
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			buf = append(buf, r)
+			inWord = true
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && quote == 0:
+			if i+1 < len(runes) {
+				i++
+				buf = append(buf, runes[i])
+				inWord = true
+			}
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			buf = append(buf, r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+	return words, nil
+}
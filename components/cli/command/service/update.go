@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/spf13/pflag"
+
+	"github.com/spf13/cobra"
+)
+
+func newUpdateCommand(dockerCli command.Cli) *cobra.Command {
+	opts := newServiceOptions()
+	var rollbackFlag, force bool
+	var placementPrefAdd, placementPrefRemove placementPrefOpts
+	var configAdd ConfigOpt
+	var genericResourcesAdd genericResourceOpts
+
+	cmd := &cobra.Command{
+		Use:   "update [OPTIONS] SERVICE",
+		Short: "Update a service",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(dockerCli, cmd.Flags(), opts, rollbackFlag, force, &placementPrefAdd, &placementPrefRemove, &configAdd, &genericResourcesAdd, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	addServiceFlags(cmd, opts)
+
+	flags.StringSlice(flagEnvAdd, []string{}, "Add or update an environment variable")
+	flags.StringSlice(flagEnvRemove, []string{}, "Remove an environment variable")
+
+	flags.Var(&placementPrefAdd, flagPlacementPrefAdd, "Add a placement preference")
+	flags.Var(&placementPrefRemove, flagPlacementPrefRemove, "Remove a placement preference")
+
+	flags.Var(&configAdd, flagConfigAdd, "Add or update a config file on a service")
+	flags.StringSlice(flagConfigRemove, []string{}, "Remove a configuration file")
+
+	flags.Var(&genericResourcesAdd, flagGenericResourcesAdd, "Add a generic resource")
+	flags.StringSlice(flagGenericResourcesRemove, []string{}, "Remove a generic resource")
+
+	flags.BoolVar(&force, flagForce, false, "Force update even if no changes require it")
+	flags.BoolVar(&rollbackFlag, flagRollback, false, "Rollback to previous specification")
+
+	return cmd
+}
+
+func runUpdate(dockerCli command.Cli, flags *pflag.FlagSet, opts *serviceOptions, rollbackFlag, force bool, placementPrefAdd, placementPrefRemove *placementPrefOpts, configAdd *ConfigOpt, genericResourcesAdd *genericResourceOpts, serviceID string) error {
+	apiClient := dockerCli.Client()
+	ctx := context.Background()
+
+	service, _, err := apiClient.ServiceInspectWithRaw(ctx, serviceID, types.ServiceInspectOptions{})
+	if err != nil {
+		return err
+	}
+	spec := &service.Spec
+
+	updateOpts := types.ServiceUpdateOptions{}
+
+	if flags.Changed(flagUpdateFailureAction) {
+		if v, _ := flags.GetString(flagUpdateFailureAction); v == "rollback" && spec.RollbackConfig == nil &&
+			!anyChanged(flags, flagRollbackParallelism, flagRollbackDelay, flagRollbackMonitor, flagRollbackFailureAction, flagRollbackMaxFailureRatio, flagRollbackOrder) {
+			return fmt.Errorf("update-failure-action=rollback requires a rollback configuration")
+		}
+	}
+
+	if rollbackFlag {
+		updateOpts.Rollback = "previous"
+	} else {
+		if force {
+			spec.TaskTemplate.ForceUpdate++
+		}
+
+		if err := updatePlacementPreferences(flags, spec.TaskTemplate.Placement, placementPrefAdd, placementPrefRemove); err != nil {
+			return err
+		}
+
+		if err := updateEnvironment(flags, opts, &spec.TaskTemplate.ContainerSpec); err != nil {
+			return err
+		}
+
+		if err := updateConfigs(flags, configAdd, &spec.TaskTemplate.ContainerSpec); err != nil {
+			return err
+		}
+
+		if err := updateGenericResources(flags, genericResourcesAdd, spec.TaskTemplate.Resources); err != nil {
+			return err
+		}
+
+		if anyChanged(flags, flagRollbackParallelism, flagRollbackDelay, flagRollbackMonitor, flagRollbackFailureAction, flagRollbackMaxFailureRatio, flagRollbackOrder) {
+			spec.RollbackConfig = &swarm.UpdateConfig{
+				Parallelism:     opts.rollback.parallelism,
+				Delay:           opts.rollback.delay,
+				Monitor:         opts.rollback.monitor,
+				FailureAction:   opts.rollback.onFailure,
+				MaxFailureRatio: opts.rollback.maxFailureRatio.Value(),
+				Order:           opts.rollback.order,
+			}
+		}
+
+		if anyChanged(flags, flagUpdateParallelism, flagUpdateDelay, flagUpdateMonitor, flagUpdateFailureAction, flagUpdateMaxFailureRatio, flagUpdateOrder) {
+			if spec.UpdateConfig == nil {
+				spec.UpdateConfig = &swarm.UpdateConfig{}
+			}
+			if anyChanged(flags, flagUpdateParallelism) {
+				spec.UpdateConfig.Parallelism = opts.update.parallelism
+			}
+			if anyChanged(flags, flagUpdateDelay) {
+				spec.UpdateConfig.Delay = opts.update.delay
+			}
+			if anyChanged(flags, flagUpdateMonitor) {
+				spec.UpdateConfig.Monitor = opts.update.monitor
+			}
+			if anyChanged(flags, flagUpdateFailureAction) {
+				spec.UpdateConfig.FailureAction = opts.update.onFailure
+			}
+			if anyChanged(flags, flagUpdateMaxFailureRatio) {
+				spec.UpdateConfig.MaxFailureRatio = opts.update.maxFailureRatio.Value()
+			}
+			if anyChanged(flags, flagUpdateOrder) {
+				spec.UpdateConfig.Order = opts.update.order
+			}
+		}
+	}
+
+	response, err := apiClient.ServiceUpdate(ctx, service.ID, service.Version, *spec, updateOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range response.Warnings {
+		fmt.Fprintln(dockerCli.Err(), warning)
+	}
+
+	fmt.Fprintln(dockerCli.Out(), serviceID)
+	return nil
+}
+
+// updatePlacementPreferences updates the placement preferences of a service
+// based on the `--placement-pref-add` and `--placement-pref-rm` flags. Both
+// flags are registered with flags.Var(&placementPrefOpts{}, ...) rather than
+// flags.StringSlice, since a preference's own syntax is itself comma
+// separated (e.g. "strategy=spread,spread=node.labels.zone") and would
+// otherwise be torn apart by StringSlice's CSV-row splitting.
+func updatePlacementPreferences(flags *pflag.FlagSet, placement *swarm.Placement, addPrefs, removePrefs *placementPrefOpts) error {
+	if flags.Changed(flagPlacementPrefAdd) {
+		placement.Preferences = append(placement.Preferences, addPrefs.prefs...)
+	}
+
+	if flags.Changed(flagPlacementPrefRemove) {
+		for i, target := range removePrefs.prefs {
+			var newPrefs []swarm.PlacementPreference
+			found := false
+			for _, pref := range placement.Preferences {
+				if !found && placementPreferenceEqual(pref, target) {
+					found = true
+					continue
+				}
+				newPrefs = append(newPrefs, pref)
+			}
+			if !found {
+				return fmt.Errorf("could not find a matching placement preference to remove: %v", removePrefs.strings[i])
+			}
+			placement.Preferences = newPrefs
+		}
+	}
+
+	return nil
+}
+
+// updateEnvironment updates the environment variables on a service's
+// ContainerSpec based on the `--env-add` and `--env-rm` flags. Adding a key
+// that already exists overrides it in place, mirroring `processEnv`'s
+// dedup-to-last-value behavior used on create.
+func updateEnvironment(flags *pflag.FlagSet, opts *serviceOptions, container *swarm.ContainerSpec) error {
+	if flags.Changed(flagEnvAdd) {
+		envAdd, err := flags.GetStringSlice(flagEnvAdd)
+		if err != nil {
+			return err
+		}
+		for i, v := range envAdd {
+			if opts.envExpand {
+				v = expandEnvVar(v)
+			}
+			envAdd[i] = v
+		}
+		container.Env = orderedDedupEnv(append(container.Env, envAdd...))
+	}
+
+	if flags.Changed(flagEnvRemove) {
+		names, err := flags.GetStringSlice(flagEnvRemove)
+		if err != nil {
+			return err
+		}
+		remove := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			remove[name] = struct{}{}
+		}
+
+		var env []string
+		for _, v := range container.Env {
+			if _, ok := remove[strings.SplitN(v, "=", 2)[0]]; ok {
+				continue
+			}
+			env = append(env, v)
+		}
+		container.Env = env
+	}
+
+	return nil
+}
+
+// updateConfigs updates the configs attached to a service's ContainerSpec
+// based on the `--config-add` and `--config-rm` flags.
+func updateConfigs(flags *pflag.FlagSet, configAdd *ConfigOpt, container *swarm.ContainerSpec) error {
+	if flags.Changed(flagConfigAdd) {
+		container.Configs = append(container.Configs, convertConfigs(configAdd.Value())...)
+	}
+
+	if flags.Changed(flagConfigRemove) {
+		names, err := flags.GetStringSlice(flagConfigRemove)
+		if err != nil {
+			return err
+		}
+		remove := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			remove[name] = struct{}{}
+		}
+
+		var configs []*swarm.ConfigReference
+		for _, config := range container.Configs {
+			if _, ok := remove[config.ConfigName]; ok {
+				continue
+			}
+			configs = append(configs, config)
+		}
+		container.Configs = configs
+	}
+
+	return nil
+}
+
+// updateGenericResources updates the generic resources (e.g. GPUs) attached
+// to a service's limits and reservations, based on the
+// `--generic-resource-add` and `--generic-resource-rm` flags. Merging is
+// keyed on the resource Kind, so re-adding a Kind replaces its value rather
+// than appending a duplicate.
+func updateGenericResources(flags *pflag.FlagSet, genericResourcesAdd *genericResourceOpts, resources *swarm.ResourceRequirements) error {
+	if !flags.Changed(flagGenericResourcesAdd) && !flags.Changed(flagGenericResourcesRemove) {
+		return nil
+	}
+	if resources == nil {
+		return fmt.Errorf("no resource requirements found on service, cannot update generic resources")
+	}
+
+	merge := func(current []swarm.GenericResource) []swarm.GenericResource {
+		if flags.Changed(flagGenericResourcesAdd) {
+			for _, toAdd := range genericResourcesAdd.Value() {
+				current = mergeGenericResource(current, toAdd)
+			}
+		}
+		if flags.Changed(flagGenericResourcesRemove) {
+			names, _ := flags.GetStringSlice(flagGenericResourcesRemove)
+			remove := make(map[string]struct{}, len(names))
+			for _, name := range names {
+				remove[name] = struct{}{}
+			}
+			var kept []swarm.GenericResource
+			for _, res := range current {
+				if _, ok := remove[genericResourceKind(res)]; ok {
+					continue
+				}
+				kept = append(kept, res)
+			}
+			current = kept
+		}
+		return current
+	}
+
+	if resources.Limits != nil {
+		resources.Limits.GenericResources = merge(resources.Limits.GenericResources)
+	}
+	if resources.Reservations != nil {
+		resources.Reservations.GenericResources = merge(resources.Reservations.GenericResources)
+	}
+	return nil
+}
+
+func mergeGenericResource(current []swarm.GenericResource, toAdd swarm.GenericResource) []swarm.GenericResource {
+	kind := genericResourceKind(toAdd)
+	for i, res := range current {
+		if genericResourceKind(res) == kind {
+			current[i] = toAdd
+			return current
+		}
+	}
+	return append(current, toAdd)
+}
+
+func genericResourceKind(res swarm.GenericResource) string {
+	switch {
+	case res.DiscreteResourceSpec != nil:
+		return res.DiscreteResourceSpec.Kind
+	case res.NamedResourceSpec != nil:
+		return res.NamedResourceSpec.Kind
+	}
+	return ""
+}
+
+func placementPreferenceEqual(a, b swarm.PlacementPreference) bool {
+	if a.Spread == nil || b.Spread == nil {
+		return a.Spread == b.Spread
+	}
+	return a.Spread.SpreadDescriptor == b.Spread.SpreadDescriptor
+}